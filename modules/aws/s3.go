@@ -3,9 +3,15 @@ package aws
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"net/url"
+	"os"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
@@ -14,6 +20,7 @@ import (
 	"github.com/gruntwork-io/terratest/modules/logger"
 	"github.com/gruntwork-io/terratest/modules/testing"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
 )
 
 // FindS3BucketWithTag finds the name of the S3 bucket in the given region with the given tag key=value.
@@ -154,6 +161,13 @@ func PutS3ObjectContentsE(t testing.TestingT, awsRegion string, bucket string, k
 	return err
 }
 
+// S3BucketOptions configures optional settings applied when creating an S3 bucket.
+type S3BucketOptions struct {
+	// ObjectLockEnabledForBucket enables S3 Object Lock on the bucket. This can only be set at creation time;
+	// use PutS3BucketObjectLockConfigurationE afterwards to configure a default retention mode and period.
+	ObjectLockEnabledForBucket bool
+}
+
 // CreateS3Bucket creates an S3 bucket in the given region with the given name. Note that S3 bucket names must be globally unique.
 func CreateS3Bucket(t testing.TestingT, region string, name string) {
 	err := CreateS3BucketE(t, region, name)
@@ -162,6 +176,19 @@ func CreateS3Bucket(t testing.TestingT, region string, name string) {
 
 // CreateS3BucketE creates an S3 bucket in the given region with the given name. Note that S3 bucket names must be globally unique.
 func CreateS3BucketE(t testing.TestingT, region string, name string) error {
+	return CreateS3BucketWithOptionsE(t, region, name, S3BucketOptions{})
+}
+
+// CreateS3BucketWithOptions creates an S3 bucket in the given region with the given name and options. Note that
+// S3 bucket names must be globally unique.
+func CreateS3BucketWithOptions(t testing.TestingT, region string, name string, opts S3BucketOptions) {
+	err := CreateS3BucketWithOptionsE(t, region, name, opts)
+	require.NoError(t, err)
+}
+
+// CreateS3BucketWithOptionsE creates an S3 bucket in the given region with the given name and options. Note
+// that S3 bucket names must be globally unique.
+func CreateS3BucketWithOptionsE(t testing.TestingT, region string, name string, opts S3BucketOptions) error {
 	logger.Default.Logf(t, "Creating bucket %s in %s", name, region)
 
 	s3Client, err := NewS3ClientE(t, region)
@@ -180,6 +207,10 @@ func CreateS3BucketE(t testing.TestingT, region string, name string) error {
 		}
 	}
 
+	if opts.ObjectLockEnabledForBucket {
+		params.ObjectLockEnabledForBucket = aws.Bool(true)
+	}
+
 	_, err = s3Client.CreateBucket(context.Background(), params)
 	return err
 }
@@ -257,14 +288,47 @@ func DeleteS3BucketE(t testing.TestingT, region string, name string) error {
 	return err
 }
 
+// defaultEmptyS3BucketConcurrency is the number of DeleteObjects batches EmptyS3BucketE runs in parallel when
+// the caller doesn't specify a concurrency via S3EmptyBucketOptions.
+const defaultEmptyS3BucketConcurrency = 10
+
+// S3EmptyBucketOptions configures how EmptyS3BucketWithOptionsE paginates and deletes object versions.
+type S3EmptyBucketOptions struct {
+	// Concurrency is the number of DeleteObjects batches (up to 1000 keys each) run in parallel. Defaults to
+	// defaultEmptyS3BucketConcurrency when 0.
+	Concurrency int
+	// RequestTimeout bounds each individual DeleteObjects call. No timeout is applied when 0.
+	RequestTimeout time.Duration
+	// BypassGovernanceRetention allows deleting versions protected by governance-mode object lock retention.
+	BypassGovernanceRetention bool
+	// Prefix, if set, restricts deletion to object versions whose key starts with this prefix.
+	Prefix string
+}
+
 // EmptyS3Bucket removes the contents of an S3 bucket in the given region with the given name.
 func EmptyS3Bucket(t testing.TestingT, region string, name string) {
 	err := EmptyS3BucketE(t, region, name)
 	require.NoError(t, err)
 }
 
-// EmptyS3BucketE removes the contents of an S3 bucket in the given region with the given name.
+// EmptyS3BucketE removes the contents of an S3 bucket in the given region with the given name, paginating and
+// deleting object versions concurrently. See EmptyS3BucketWithOptionsE to customize concurrency, per-request
+// timeout, a key prefix filter, or to bypass governance-mode object lock retention.
 func EmptyS3BucketE(t testing.TestingT, region string, name string) error {
+	return EmptyS3BucketWithOptionsE(t, region, name, S3EmptyBucketOptions{})
+}
+
+// EmptyS3BucketWithOptions removes the contents of an S3 bucket in the given region with the given name,
+// according to the given options.
+func EmptyS3BucketWithOptions(t testing.TestingT, region string, name string, opts S3EmptyBucketOptions) {
+	err := EmptyS3BucketWithOptionsE(t, region, name, opts)
+	require.NoError(t, err)
+}
+
+// EmptyS3BucketWithOptionsE removes the contents of an S3 bucket in the given region with the given name,
+// according to the given options. Object versions are paginated via ListObjectVersionsPaginator and deleted in
+// batches of up to 1000 keys, with up to opts.Concurrency batches in flight at once.
+func EmptyS3BucketWithOptionsE(t testing.TestingT, region string, name string, opts S3EmptyBucketOptions) error {
 	logger.Default.Logf(t, "Emptying bucket %s in %s", name, region)
 
 	s3Client, err := NewS3ClientE(t, region)
@@ -272,66 +336,91 @@ func EmptyS3BucketE(t testing.TestingT, region string, name string) error {
 		return err
 	}
 
-	params := &s3.ListObjectVersionsInput{
-		Bucket: aws.String(name),
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultEmptyS3BucketConcurrency
 	}
 
-	for {
-		// Requesting a batch of objects from s3 bucket
-		bucketObjects, err := s3Client.ListObjectVersions(context.Background(), params)
+	paginator := s3.NewListObjectVersionsPaginator(s3Client, &s3.ListObjectVersionsInput{
+		Bucket: aws.String(name),
+		Prefix: aws.String(opts.Prefix),
+	})
+
+	group, ctx := errgroup.WithContext(context.Background())
+	group.SetLimit(concurrency)
+
+	deletedCount := 0
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
 		if err != nil {
-			return err
+			return errors.Join(err, group.Wait())
 		}
 
-		// Checks if the bucket is already empty
-		if len((*bucketObjects).Versions) == 0 {
-			logger.Default.Logf(t, "Bucket %s is already empty", name)
-			return nil
+		objectsToDelete := make([]types.ObjectIdentifier, 0, len(page.Versions)+len(page.DeleteMarkers))
+		for _, object := range page.Versions {
+			objectsToDelete = append(objectsToDelete, types.ObjectIdentifier{Key: object.Key, VersionId: object.VersionId})
 		}
-
-		// creating an array of pointers of ObjectIdentifier
-		objectsToDelete := make([]types.ObjectIdentifier, 0, 1000)
-		for _, object := range (*bucketObjects).Versions {
-			obj := types.ObjectIdentifier{
-				Key:       object.Key,
-				VersionId: object.VersionId,
-			}
-			objectsToDelete = append(objectsToDelete, obj)
+		for _, object := range page.DeleteMarkers {
+			objectsToDelete = append(objectsToDelete, types.ObjectIdentifier{Key: object.Key, VersionId: object.VersionId})
 		}
 
-		for _, object := range (*bucketObjects).DeleteMarkers {
-			obj := types.ObjectIdentifier{
-				Key:       object.Key,
-				VersionId: object.VersionId,
-			}
-			objectsToDelete = append(objectsToDelete, obj)
+		for _, batch := range chunkObjectIdentifiers(objectsToDelete, 1000) {
+			batch := batch
+			deletedCount += len(batch)
+			group.Go(func() error {
+				return deleteS3ObjectBatch(ctx, s3Client, name, batch, opts)
+			})
 		}
+	}
 
-		// Creating JSON payload for bulk delete
-		deleteArray := types.Delete{Objects: objectsToDelete}
-		deleteParams := &s3.DeleteObjectsInput{
-			Bucket: aws.String(name),
-			Delete: &deleteArray,
-		}
+	if err := group.Wait(); err != nil {
+		return err
+	}
 
-		// Running the Bulk delete job (limit 1000)
-		_, err = s3Client.DeleteObjects(context.Background(), deleteParams)
-		if err != nil {
-			return err
-		}
+	if deletedCount == 0 {
+		logger.Default.Logf(t, "Bucket %s is already empty", name)
+	} else {
+		logger.Default.Logf(t, "Bucket %s is now empty (%d object versions deleted)", name, deletedCount)
+	}
 
-		if *(*bucketObjects).IsTruncated { // if there are more objects in the bucket, IsTruncated = true
-			// params.Marker = (*deleteParams).Delete.Objects[len((*deleteParams).Delete.Objects)-1].Key
-			params.KeyMarker = bucketObjects.NextKeyMarker
-			logger.Default.Logf(t, "Requesting next batch | %s", *(params.KeyMarker))
-		} else { // if all objects in the bucket have been cleaned up.
-			break
-		}
+	return nil
+}
+
+// deleteS3ObjectBatch issues a single DeleteObjects call for up to 1000 object identifiers, honoring the
+// request timeout and governance retention bypass configured in opts.
+func deleteS3ObjectBatch(ctx context.Context, s3Client *s3.Client, bucket string, batch []types.ObjectIdentifier, opts S3EmptyBucketOptions) error {
+	if opts.RequestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.RequestTimeout)
+		defer cancel()
+	}
+
+	deleteParams := &s3.DeleteObjectsInput{
+		Bucket: aws.String(bucket),
+		Delete: &types.Delete{Objects: batch},
+	}
+	if opts.BypassGovernanceRetention {
+		deleteParams.BypassGovernanceRetention = aws.Bool(true)
 	}
-	logger.Default.Logf(t, "Bucket %s is now empty", name)
+
+	_, err := s3Client.DeleteObjects(ctx, deleteParams)
 	return err
 }
 
+// chunkObjectIdentifiers splits the given object identifiers into batches of at most size elements.
+func chunkObjectIdentifiers(objects []types.ObjectIdentifier, size int) [][]types.ObjectIdentifier {
+	if len(objects) == 0 {
+		return nil
+	}
+
+	chunks := make([][]types.ObjectIdentifier, 0, (len(objects)+size-1)/size)
+	for size < len(objects) {
+		chunks = append(chunks, objects[:size:size])
+		objects = objects[size:]
+	}
+	return append(chunks, objects)
+}
+
 // GetS3BucketLoggingTarget fetches the given bucket's logging target bucket and returns it as a string
 func GetS3BucketLoggingTarget(t testing.TestingT, awsRegion string, bucket string) string {
 	loggingTarget, err := GetS3BucketLoggingTargetE(t, awsRegion, bucket)
@@ -564,12 +653,1180 @@ func NewS3UploaderE(t testing.TestingT, region string) (*manager.Uploader, error
 	return manager.NewUploader(s3.NewFromConfig(*sess)), nil
 }
 
-// S3AccessLoggingNotEnabledErr is a custom error that occurs when acess logging hasn't been enabled on the S3 Bucket
-type S3AccessLoggingNotEnabledErr struct {
-	OriginBucket string
-	Region       string
+// S3MultipartTransferOptions configures a multipart upload or download of a large S3 object.
+type S3MultipartTransferOptions struct {
+	// PartSize is the size, in bytes, of each part. Defaults to the manager package default (5 MiB) when 0.
+	PartSize int64
+	// Concurrency is the number of parts uploaded or downloaded in parallel. Defaults to the manager package
+	// default (5) when 0.
+	Concurrency int
+	// Progress, if set, is called each time data is read from the source file (upload) or written to the
+	// destination file (download), with the cumulative bytes so far and the total size of the object.
+	Progress func(bytesTransferred, total int64)
 }
 
-func (err S3AccessLoggingNotEnabledErr) Error() string {
-	return fmt.Sprintf("Server Acess Logging hasn't been enabled for S3 Bucket %s in region %s", err.OriginBucket, err.Region)
+// UploadS3FileMultipart uploads the file at filePath to the given S3 bucket and key using a concurrent
+// multipart upload, suitable for large files.
+func UploadS3FileMultipart(t testing.TestingT, region string, bucket string, key string, filePath string, opts S3MultipartTransferOptions) {
+	err := UploadS3FileMultipartE(t, region, bucket, key, filePath, opts)
+	require.NoError(t, err)
+}
+
+// UploadS3FileMultipartE uploads the file at filePath to the given S3 bucket and key using a concurrent
+// multipart upload, suitable for large files.
+func UploadS3FileMultipartE(t testing.TestingT, region string, bucket string, key string, filePath string, opts S3MultipartTransferOptions) error {
+	s3Client, err := NewS3ClientE(t, region)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var body io.Reader = file
+	if opts.Progress != nil {
+		info, err := file.Stat()
+		if err != nil {
+			return err
+		}
+		body = &progressReader{reader: file, total: info.Size(), progress: opts.Progress}
+	}
+
+	uploader := manager.NewUploader(s3Client, func(u *manager.Uploader) {
+		if opts.PartSize > 0 {
+			u.PartSize = opts.PartSize
+		}
+		if opts.Concurrency > 0 {
+			u.Concurrency = opts.Concurrency
+		}
+	})
+
+	logger.Default.Logf(t, "Uploading %s to s3://%s/%s using multipart upload", filePath, bucket, key)
+
+	_, err = uploader.Upload(context.Background(), &s3.PutObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		Body:   body,
+	})
+	return err
+}
+
+// DownloadS3FileMultipart downloads the object at the given S3 bucket and key to destPath using a concurrent
+// multipart download, suitable for large files.
+func DownloadS3FileMultipart(t testing.TestingT, region string, bucket string, key string, destPath string, opts S3MultipartTransferOptions) {
+	err := DownloadS3FileMultipartE(t, region, bucket, key, destPath, opts)
+	require.NoError(t, err)
+}
+
+// DownloadS3FileMultipartE downloads the object at the given S3 bucket and key to destPath using a concurrent
+// multipart download, suitable for large files.
+func DownloadS3FileMultipartE(t testing.TestingT, region string, bucket string, key string, destPath string, opts S3MultipartTransferOptions) error {
+	s3Client, err := NewS3ClientE(t, region)
+	if err != nil {
+		return err
+	}
+
+	destFile, err := os.Create(destPath)
+	if err != nil {
+		return err
+	}
+	defer destFile.Close()
+
+	var writer io.WriterAt = destFile
+	if opts.Progress != nil {
+		head, err := s3Client.HeadObject(context.Background(), &s3.HeadObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+		})
+		if err != nil {
+			return err
+		}
+		writer = &progressWriterAt{writerAt: destFile, total: aws.ToInt64(head.ContentLength), progress: opts.Progress}
+	}
+
+	downloader := manager.NewDownloader(s3Client, func(d *manager.Downloader) {
+		if opts.PartSize > 0 {
+			d.PartSize = opts.PartSize
+		}
+		if opts.Concurrency > 0 {
+			d.Concurrency = opts.Concurrency
+		}
+	})
+
+	logger.Default.Logf(t, "Downloading s3://%s/%s to %s using multipart download", bucket, key, destPath)
+
+	_, err = downloader.Download(context.Background(), writer, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	return err
+}
+
+// progressReader wraps an io.Reader and reports cumulative bytes read via the given progress callback.
+type progressReader struct {
+	reader      io.Reader
+	total       int64
+	transferred int64
+	progress    func(bytesTransferred, total int64)
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		transferred := atomic.AddInt64(&r.transferred, int64(n))
+		r.progress(transferred, r.total)
+	}
+	return n, err
+}
+
+// progressWriterAt wraps an io.WriterAt and reports cumulative bytes written via the given progress callback.
+type progressWriterAt struct {
+	writerAt    io.WriterAt
+	total       int64
+	transferred int64
+	progress    func(bytesTransferred, total int64)
+}
+
+func (w *progressWriterAt) WriteAt(p []byte, off int64) (int, error) {
+	n, err := w.writerAt.WriteAt(p, off)
+	if n > 0 {
+		transferred := atomic.AddInt64(&w.transferred, int64(n))
+		w.progress(transferred, w.total)
+	}
+	return n, err
+}
+
+// S3ObjectMetadata models the optional headers and metadata that can be attached to an S3 object on upload.
+type S3ObjectMetadata struct {
+	ContentType        string
+	ContentEncoding    string
+	ContentDisposition string
+	ContentLanguage    string
+	CacheControl       string
+	Metadata           map[string]string
+	ACL                types.ObjectCannedACL
+}
+
+// PutS3ObjectContentsWithMetadata puts the contents of the object in the given bucket with the given key,
+// along with the given content headers, user metadata, and ACL.
+func PutS3ObjectContentsWithMetadata(t testing.TestingT, awsRegion string, bucket string, key string, body io.Reader, metadata S3ObjectMetadata) {
+	err := PutS3ObjectContentsWithMetadataE(t, awsRegion, bucket, key, body, metadata)
+	require.NoError(t, err)
+}
+
+// PutS3ObjectContentsWithMetadataE puts the contents of the object in the given bucket with the given key,
+// along with the given content headers, user metadata, and ACL.
+func PutS3ObjectContentsWithMetadataE(t testing.TestingT, awsRegion string, bucket string, key string, body io.Reader, metadata S3ObjectMetadata) error {
+	s3Client, err := NewS3ClientE(t, awsRegion)
+	if err != nil {
+		return fmt.Errorf("failed to instantiate s3 client: %w", err)
+	}
+
+	params := &s3.PutObjectInput{
+		Bucket:   aws.String(bucket),
+		Key:      aws.String(key),
+		Body:     body,
+		ACL:      metadata.ACL,
+		Metadata: metadata.Metadata,
+	}
+
+	if metadata.ContentType != "" {
+		params.ContentType = aws.String(metadata.ContentType)
+	}
+	if metadata.ContentEncoding != "" {
+		params.ContentEncoding = aws.String(metadata.ContentEncoding)
+	}
+	if metadata.ContentDisposition != "" {
+		params.ContentDisposition = aws.String(metadata.ContentDisposition)
+	}
+	if metadata.ContentLanguage != "" {
+		params.ContentLanguage = aws.String(metadata.ContentLanguage)
+	}
+	if metadata.CacheControl != "" {
+		params.CacheControl = aws.String(metadata.CacheControl)
+	}
+
+	_, err = s3Client.PutObject(context.Background(), params)
+	return err
+}
+
+// S3LifecycleExpiration models the expiration action of an S3 lifecycle rule.
+type S3LifecycleExpiration struct {
+	Days                      int32
+	Date                      *time.Time
+	ExpiredObjectDeleteMarker bool
+}
+
+// S3LifecycleTransition models a single storage class transition of an S3 lifecycle rule.
+type S3LifecycleTransition struct {
+	Days         int32
+	Date         *time.Time
+	StorageClass string
+}
+
+// S3LifecycleNoncurrentVersionTransition models a noncurrent version storage class transition of an S3
+// lifecycle rule.
+type S3LifecycleNoncurrentVersionTransition struct {
+	NoncurrentDays int32
+	StorageClass   string
+}
+
+// S3LifecycleRule models a single S3 bucket lifecycle rule. Prefix and Tags together describe the rule's
+// filter: set Prefix alone, Tags alone (a single key/value pair), or both for a combined "and" filter.
+type S3LifecycleRule struct {
+	ID                                 string
+	Prefix                             string
+	Tags                               map[string]string
+	Status                             string
+	Expiration                         *S3LifecycleExpiration
+	NoncurrentVersionExpirationDays    int32
+	Transitions                        []S3LifecycleTransition
+	NoncurrentVersionTransitions       []S3LifecycleNoncurrentVersionTransition
+	AbortIncompleteMultipartUploadDays int32
+}
+
+// PutS3BucketLifecycle applies the given lifecycle rules to an S3 bucket, replacing any existing lifecycle
+// configuration.
+func PutS3BucketLifecycle(t testing.TestingT, region string, bucket string, rules []S3LifecycleRule) {
+	err := PutS3BucketLifecycleE(t, region, bucket, rules)
+	require.NoError(t, err)
+}
+
+// PutS3BucketLifecycleE applies the given lifecycle rules to an S3 bucket, replacing any existing lifecycle
+// configuration.
+func PutS3BucketLifecycleE(t testing.TestingT, region string, bucket string, rules []S3LifecycleRule) error {
+	logger.Default.Logf(t, "Applying lifecycle configuration for bucket %s in %s", bucket, region)
+
+	s3Client, err := NewS3ClientE(t, region)
+	if err != nil {
+		return err
+	}
+
+	lifecycleRules := make([]types.LifecycleRule, 0, len(rules))
+	for _, rule := range rules {
+		lifecycleRules = append(lifecycleRules, toLifecycleRule(rule))
+	}
+
+	input := &s3.PutBucketLifecycleConfigurationInput{
+		Bucket: aws.String(bucket),
+		LifecycleConfiguration: &types.BucketLifecycleConfiguration{
+			Rules: lifecycleRules,
+		},
+	}
+
+	_, err = s3Client.PutBucketLifecycleConfiguration(context.Background(), input)
+	return err
+}
+
+// GetS3BucketLifecycle fetches the lifecycle rules configured on the given S3 bucket.
+func GetS3BucketLifecycle(t testing.TestingT, region string, bucket string) []S3LifecycleRule {
+	rules, err := GetS3BucketLifecycleE(t, region, bucket)
+	require.NoError(t, err)
+
+	return rules
+}
+
+// GetS3BucketLifecycleE fetches the lifecycle rules configured on the given S3 bucket.
+func GetS3BucketLifecycleE(t testing.TestingT, region string, bucket string) ([]S3LifecycleRule, error) {
+	s3Client, err := NewS3ClientE(t, region)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := s3Client.GetBucketLifecycleConfiguration(context.Background(), &s3.GetBucketLifecycleConfigurationInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]S3LifecycleRule, 0, len(out.Rules))
+	for _, rule := range out.Rules {
+		rules = append(rules, fromLifecycleRule(rule))
+	}
+
+	return rules, nil
+}
+
+// DeleteS3BucketLifecycle removes the lifecycle configuration from the given S3 bucket.
+func DeleteS3BucketLifecycle(t testing.TestingT, region string, bucket string) {
+	err := DeleteS3BucketLifecycleE(t, region, bucket)
+	require.NoError(t, err)
+}
+
+// DeleteS3BucketLifecycleE removes the lifecycle configuration from the given S3 bucket.
+func DeleteS3BucketLifecycleE(t testing.TestingT, region string, bucket string) error {
+	logger.Default.Logf(t, "Deleting lifecycle configuration for bucket %s in %s", bucket, region)
+
+	s3Client, err := NewS3ClientE(t, region)
+	if err != nil {
+		return err
+	}
+
+	_, err = s3Client.DeleteBucketLifecycle(context.Background(), &s3.DeleteBucketLifecycleInput{
+		Bucket: aws.String(bucket),
+	})
+	return err
+}
+
+// AssertS3BucketLifecycleRuleExists checks that the given S3 bucket has a lifecycle rule with the given ID and
+// fails the test if it does not.
+func AssertS3BucketLifecycleRuleExists(t testing.TestingT, region string, bucket string, ruleID string) {
+	err := AssertS3BucketLifecycleRuleExistsE(t, region, bucket, ruleID)
+	require.NoError(t, err)
+}
+
+// AssertS3BucketLifecycleRuleExistsE checks that the given S3 bucket has a lifecycle rule with the given ID and
+// returns an error if it does not.
+func AssertS3BucketLifecycleRuleExistsE(t testing.TestingT, region string, bucket string, ruleID string) error {
+	rules, err := GetS3BucketLifecycleE(t, region, bucket)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		if rule.ID == ruleID {
+			return nil
+		}
+	}
+
+	return S3LifecycleRuleNotFoundErr{Bucket: bucket, Region: region, RuleID: ruleID}
+}
+
+// toLifecycleRule converts an S3LifecycleRule into the shape expected by the AWS SDK.
+func toLifecycleRule(rule S3LifecycleRule) types.LifecycleRule {
+	out := types.LifecycleRule{
+		ID:     aws.String(rule.ID),
+		Status: types.ExpirationStatus(rule.Status),
+		Filter: toLifecycleRuleFilter(rule.Prefix, rule.Tags),
+	}
+
+	if rule.Expiration != nil {
+		out.Expiration = &types.LifecycleExpiration{
+			Date: rule.Expiration.Date,
+		}
+		if rule.Expiration.Days > 0 {
+			out.Expiration.Days = aws.Int32(rule.Expiration.Days)
+		}
+		if rule.Expiration.ExpiredObjectDeleteMarker {
+			out.Expiration.ExpiredObjectDeleteMarker = aws.Bool(true)
+		}
+	}
+
+	if rule.NoncurrentVersionExpirationDays > 0 {
+		out.NoncurrentVersionExpiration = &types.NoncurrentVersionExpiration{
+			NoncurrentDays: aws.Int32(rule.NoncurrentVersionExpirationDays),
+		}
+	}
+
+	for _, transition := range rule.Transitions {
+		sdkTransition := types.Transition{
+			Date:         transition.Date,
+			StorageClass: types.TransitionStorageClass(transition.StorageClass),
+		}
+		if transition.Date == nil {
+			sdkTransition.Days = aws.Int32(transition.Days)
+		}
+		out.Transitions = append(out.Transitions, sdkTransition)
+	}
+
+	for _, transition := range rule.NoncurrentVersionTransitions {
+		out.NoncurrentVersionTransitions = append(out.NoncurrentVersionTransitions, types.NoncurrentVersionTransition{
+			NoncurrentDays: aws.Int32(transition.NoncurrentDays),
+			StorageClass:   types.TransitionStorageClass(transition.StorageClass),
+		})
+	}
+
+	if rule.AbortIncompleteMultipartUploadDays > 0 {
+		out.AbortIncompleteMultipartUpload = &types.AbortIncompleteMultipartUpload{
+			DaysAfterInitiation: aws.Int32(rule.AbortIncompleteMultipartUploadDays),
+		}
+	}
+
+	return out
+}
+
+// toLifecycleRuleFilter builds a lifecycle rule filter from the given prefix and tags, combining them with an
+// "and" operator when both are present or when more than one tag is given.
+func toLifecycleRuleFilter(prefix string, tags map[string]string) *types.LifecycleRuleFilter {
+	switch {
+	case prefix == "" && len(tags) == 0:
+		return nil
+	case prefix != "" && len(tags) == 0:
+		return &types.LifecycleRuleFilter{Prefix: aws.String(prefix)}
+	case prefix == "" && len(tags) == 1:
+		for key, value := range tags {
+			return &types.LifecycleRuleFilter{Tag: &types.Tag{Key: aws.String(key), Value: aws.String(value)}}
+		}
+	}
+
+	and := &types.LifecycleRuleAndOperator{}
+	if prefix != "" {
+		and.Prefix = aws.String(prefix)
+	}
+	for key, value := range tags {
+		and.Tags = append(and.Tags, types.Tag{Key: aws.String(key), Value: aws.String(value)})
+	}
+
+	return &types.LifecycleRuleFilter{And: and}
+}
+
+// fromLifecycleRule converts an AWS SDK lifecycle rule into an S3LifecycleRule.
+func fromLifecycleRule(rule types.LifecycleRule) S3LifecycleRule {
+	out := S3LifecycleRule{
+		ID:     aws.ToString(rule.ID),
+		Status: string(rule.Status),
+	}
+
+	if rule.Filter != nil {
+		switch {
+		case rule.Filter.Prefix != nil:
+			out.Prefix = aws.ToString(rule.Filter.Prefix)
+		case rule.Filter.Tag != nil:
+			out.Tags = map[string]string{aws.ToString(rule.Filter.Tag.Key): aws.ToString(rule.Filter.Tag.Value)}
+		case rule.Filter.And != nil:
+			out.Prefix = aws.ToString(rule.Filter.And.Prefix)
+			out.Tags = map[string]string{}
+			for _, tag := range rule.Filter.And.Tags {
+				out.Tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+			}
+		}
+	}
+
+	if rule.Expiration != nil {
+		out.Expiration = &S3LifecycleExpiration{
+			Days:                      aws.ToInt32(rule.Expiration.Days),
+			Date:                      rule.Expiration.Date,
+			ExpiredObjectDeleteMarker: aws.ToBool(rule.Expiration.ExpiredObjectDeleteMarker),
+		}
+	}
+
+	if rule.NoncurrentVersionExpiration != nil {
+		out.NoncurrentVersionExpirationDays = aws.ToInt32(rule.NoncurrentVersionExpiration.NoncurrentDays)
+	}
+
+	for _, transition := range rule.Transitions {
+		out.Transitions = append(out.Transitions, S3LifecycleTransition{
+			Days:         aws.ToInt32(transition.Days),
+			Date:         transition.Date,
+			StorageClass: string(transition.StorageClass),
+		})
+	}
+
+	for _, transition := range rule.NoncurrentVersionTransitions {
+		out.NoncurrentVersionTransitions = append(out.NoncurrentVersionTransitions, S3LifecycleNoncurrentVersionTransition{
+			NoncurrentDays: aws.ToInt32(transition.NoncurrentDays),
+			StorageClass:   string(transition.StorageClass),
+		})
+	}
+
+	if rule.AbortIncompleteMultipartUpload != nil {
+		out.AbortIncompleteMultipartUploadDays = aws.ToInt32(rule.AbortIncompleteMultipartUpload.DaysAfterInitiation)
+	}
+
+	return out
+}
+
+// S3ObjectLockConfig models an S3 bucket's default Object Lock retention rule.
+type S3ObjectLockConfig struct {
+	// Mode is either "GOVERNANCE" or "COMPLIANCE".
+	Mode string
+	// Days is the default retention period in days. Mutually exclusive with Years.
+	Days int32
+	// Years is the default retention period in years. Mutually exclusive with Days.
+	Years int32
+}
+
+// PutS3BucketObjectLockConfiguration applies the given default Object Lock retention rule to an S3 bucket.
+// Object Lock must already be enabled on the bucket (see S3BucketOptions.ObjectLockEnabledForBucket).
+func PutS3BucketObjectLockConfiguration(t testing.TestingT, region string, bucket string, config S3ObjectLockConfig) {
+	err := PutS3BucketObjectLockConfigurationE(t, region, bucket, config)
+	require.NoError(t, err)
+}
+
+// PutS3BucketObjectLockConfigurationE applies the given default Object Lock retention rule to an S3 bucket.
+// Object Lock must already be enabled on the bucket (see S3BucketOptions.ObjectLockEnabledForBucket).
+func PutS3BucketObjectLockConfigurationE(t testing.TestingT, region string, bucket string, config S3ObjectLockConfig) error {
+	logger.Default.Logf(t, "Applying object lock configuration for bucket %s in %s", bucket, region)
+
+	s3Client, err := NewS3ClientE(t, region)
+	if err != nil {
+		return err
+	}
+
+	defaultRetention := &types.DefaultRetention{
+		Mode: types.ObjectLockRetentionMode(config.Mode),
+	}
+	if config.Days > 0 {
+		defaultRetention.Days = aws.Int32(config.Days)
+	}
+	if config.Years > 0 {
+		defaultRetention.Years = aws.Int32(config.Years)
+	}
+
+	input := &s3.PutObjectLockConfigurationInput{
+		Bucket: aws.String(bucket),
+		ObjectLockConfiguration: &types.ObjectLockConfiguration{
+			ObjectLockEnabled: types.ObjectLockEnabledEnabled,
+			Rule: &types.ObjectLockRule{
+				DefaultRetention: defaultRetention,
+			},
+		},
+	}
+
+	_, err = s3Client.PutObjectLockConfiguration(context.Background(), input)
+	return err
+}
+
+// GetS3BucketObjectLockConfiguration fetches the default Object Lock retention rule configured on the given S3
+// bucket.
+func GetS3BucketObjectLockConfiguration(t testing.TestingT, region string, bucket string) S3ObjectLockConfig {
+	config, err := GetS3BucketObjectLockConfigurationE(t, region, bucket)
+	require.NoError(t, err)
+
+	return config
+}
+
+// GetS3BucketObjectLockConfigurationE fetches the default Object Lock retention rule configured on the given S3
+// bucket.
+func GetS3BucketObjectLockConfigurationE(t testing.TestingT, region string, bucket string) (S3ObjectLockConfig, error) {
+	s3Client, err := NewS3ClientE(t, region)
+	if err != nil {
+		return S3ObjectLockConfig{}, err
+	}
+
+	out, err := s3Client.GetObjectLockConfiguration(context.Background(), &s3.GetObjectLockConfigurationInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		return S3ObjectLockConfig{}, err
+	}
+
+	config := out.ObjectLockConfiguration
+	if config == nil || config.ObjectLockEnabled != types.ObjectLockEnabledEnabled {
+		return S3ObjectLockConfig{}, S3ObjectLockNotEnabledErr{Bucket: bucket, Region: region}
+	}
+
+	if config.Rule == nil || config.Rule.DefaultRetention == nil {
+		return S3ObjectLockConfig{}, nil
+	}
+
+	return S3ObjectLockConfig{
+		Mode:  string(config.Rule.DefaultRetention.Mode),
+		Days:  aws.ToInt32(config.Rule.DefaultRetention.Days),
+		Years: aws.ToInt32(config.Rule.DefaultRetention.Years),
+	}, nil
+}
+
+// AssertS3BucketObjectLockEnabled checks that the given S3 bucket has Object Lock enabled and fails the test if
+// it does not.
+func AssertS3BucketObjectLockEnabled(t testing.TestingT, region string, bucket string) {
+	err := AssertS3BucketObjectLockEnabledE(t, region, bucket)
+	require.NoError(t, err)
+}
+
+// AssertS3BucketObjectLockEnabledE checks that the given S3 bucket has Object Lock enabled and returns an error
+// if it does not.
+func AssertS3BucketObjectLockEnabledE(t testing.TestingT, region string, bucket string) error {
+	_, err := GetS3BucketObjectLockConfigurationE(t, region, bucket)
+	return err
+}
+
+// PutS3ObjectRetention applies an Object Lock retention period to the given S3 object.
+func PutS3ObjectRetention(t testing.TestingT, region string, bucket string, key string, mode string, retainUntil time.Time) {
+	err := PutS3ObjectRetentionE(t, region, bucket, key, mode, retainUntil)
+	require.NoError(t, err)
+}
+
+// PutS3ObjectRetentionE applies an Object Lock retention period to the given S3 object.
+func PutS3ObjectRetentionE(t testing.TestingT, region string, bucket string, key string, mode string, retainUntil time.Time) error {
+	s3Client, err := NewS3ClientE(t, region)
+	if err != nil {
+		return err
+	}
+
+	_, err = s3Client.PutObjectRetention(context.Background(), &s3.PutObjectRetentionInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		Retention: &types.ObjectLockRetention{
+			Mode:            types.ObjectLockRetentionMode(mode),
+			RetainUntilDate: aws.Time(retainUntil),
+		},
+	})
+	return err
+}
+
+// GetS3ObjectRetention fetches the Object Lock retention mode and retain-until date for the given S3 object.
+func GetS3ObjectRetention(t testing.TestingT, region string, bucket string, key string) (string, time.Time) {
+	mode, retainUntil, err := GetS3ObjectRetentionE(t, region, bucket, key)
+	require.NoError(t, err)
+
+	return mode, retainUntil
+}
+
+// GetS3ObjectRetentionE fetches the Object Lock retention mode and retain-until date for the given S3 object.
+func GetS3ObjectRetentionE(t testing.TestingT, region string, bucket string, key string) (string, time.Time, error) {
+	s3Client, err := NewS3ClientE(t, region)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	out, err := s3Client.GetObjectRetention(context.Background(), &s3.GetObjectRetentionInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	if out.Retention == nil {
+		return "", time.Time{}, nil
+	}
+
+	return string(out.Retention.Mode), aws.ToTime(out.Retention.RetainUntilDate), nil
+}
+
+// PutS3ObjectLegalHold sets the Object Lock legal hold status for the given S3 object.
+func PutS3ObjectLegalHold(t testing.TestingT, region string, bucket string, key string, enabled bool) {
+	err := PutS3ObjectLegalHoldE(t, region, bucket, key, enabled)
+	require.NoError(t, err)
+}
+
+// PutS3ObjectLegalHoldE sets the Object Lock legal hold status for the given S3 object.
+func PutS3ObjectLegalHoldE(t testing.TestingT, region string, bucket string, key string, enabled bool) error {
+	s3Client, err := NewS3ClientE(t, region)
+	if err != nil {
+		return err
+	}
+
+	status := types.ObjectLockLegalHoldStatusOff
+	if enabled {
+		status = types.ObjectLockLegalHoldStatusOn
+	}
+
+	_, err = s3Client.PutObjectLegalHold(context.Background(), &s3.PutObjectLegalHoldInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(key),
+		LegalHold: &types.ObjectLockLegalHold{Status: status},
+	})
+	return err
+}
+
+// GetS3ObjectLegalHold fetches whether the given S3 object has an Object Lock legal hold applied.
+func GetS3ObjectLegalHold(t testing.TestingT, region string, bucket string, key string) bool {
+	enabled, err := GetS3ObjectLegalHoldE(t, region, bucket, key)
+	require.NoError(t, err)
+
+	return enabled
+}
+
+// GetS3ObjectLegalHoldE fetches whether the given S3 object has an Object Lock legal hold applied.
+func GetS3ObjectLegalHoldE(t testing.TestingT, region string, bucket string, key string) (bool, error) {
+	s3Client, err := NewS3ClientE(t, region)
+	if err != nil {
+		return false, err
+	}
+
+	out, err := s3Client.GetObjectLegalHold(context.Background(), &s3.GetObjectLegalHoldInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return out.LegalHold != nil && out.LegalHold.Status == types.ObjectLockLegalHoldStatusOn, nil
+}
+
+// PresignS3GetObject generates a presigned URL for downloading the given S3 object that expires after the given duration.
+func PresignS3GetObject(t testing.TestingT, awsRegion string, bucket string, key string, expiry time.Duration) string {
+	url, err := PresignS3GetObjectE(t, awsRegion, bucket, key, expiry)
+	require.NoError(t, err)
+
+	return url
+}
+
+// PresignS3GetObjectE generates a presigned URL for downloading the given S3 object that expires after the given duration.
+func PresignS3GetObjectE(t testing.TestingT, awsRegion string, bucket string, key string, expiry time.Duration) (string, error) {
+	s3Client, err := NewS3ClientE(t, awsRegion)
+	if err != nil {
+		return "", err
+	}
+
+	presignClient := s3.NewPresignClient(s3Client)
+
+	req, err := presignClient.PresignGetObject(context.Background(), &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(expiry))
+	if err != nil {
+		return "", err
+	}
+
+	logger.Default.Logf(t, "Generated presigned GET URL for s3://%s/%s, expiring in %s", bucket, key, expiry)
+
+	return req.URL, nil
+}
+
+// PresignS3PutObject generates a presigned URL for uploading an object to S3 that expires after the given duration.
+func PresignS3PutObject(t testing.TestingT, awsRegion string, bucket string, key string, expiry time.Duration) string {
+	url, err := PresignS3PutObjectE(t, awsRegion, bucket, key, expiry)
+	require.NoError(t, err)
+
+	return url
+}
+
+// PresignS3PutObjectE generates a presigned URL for uploading an object to S3 that expires after the given duration.
+func PresignS3PutObjectE(t testing.TestingT, awsRegion string, bucket string, key string, expiry time.Duration) (string, error) {
+	s3Client, err := NewS3ClientE(t, awsRegion)
+	if err != nil {
+		return "", err
+	}
+
+	presignClient := s3.NewPresignClient(s3Client)
+
+	req, err := presignClient.PresignPutObject(context.Background(), &s3.PutObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(expiry))
+	if err != nil {
+		return "", err
+	}
+
+	logger.Default.Logf(t, "Generated presigned PUT URL for s3://%s/%s, expiring in %s", bucket, key, expiry)
+
+	return req.URL, nil
+}
+
+// AssertS3PresignedURLDownloads fetches the given presigned URL and fails the test unless the response has the
+// expected status code and body. expectedQueryParams, if non-empty, asserts that the presigned URL itself
+// carries the given query parameters (e.g. "response-content-disposition", "x-amz-acl"); expectedHeaders, if
+// non-empty, asserts that the response carries the given headers (e.g. "Content-Disposition"). Either map may
+// be nil to skip that check.
+func AssertS3PresignedURLDownloads(t testing.TestingT, presignedURL string, expectedStatusCode int, expectedBody string, expectedQueryParams map[string]string, expectedHeaders map[string]string) {
+	err := AssertS3PresignedURLDownloadsE(t, presignedURL, expectedStatusCode, expectedBody, expectedQueryParams, expectedHeaders)
+	require.NoError(t, err)
+}
+
+// AssertS3PresignedURLDownloadsE fetches the given presigned URL and returns an error unless the response has
+// the expected status code and body. expectedQueryParams, if non-empty, asserts that the presigned URL itself
+// carries the given query parameters (e.g. "response-content-disposition", "x-amz-acl"); expectedHeaders, if
+// non-empty, asserts that the response carries the given headers (e.g. "Content-Disposition"). Either map may
+// be nil to skip that check.
+func AssertS3PresignedURLDownloadsE(t testing.TestingT, presignedURL string, expectedStatusCode int, expectedBody string, expectedQueryParams map[string]string, expectedHeaders map[string]string) error {
+	parsedURL, err := url.Parse(presignedURL)
+	if err != nil {
+		return err
+	}
+
+	query := parsedURL.Query()
+	for param, expectedValue := range expectedQueryParams {
+		actualValue := query.Get(param)
+		if actualValue != expectedValue {
+			return fmt.Errorf("expected presigned URL %s to have query param %s=%q, but got %q", presignedURL, param, expectedValue, actualValue)
+		}
+	}
+
+	resp, err := http.Get(presignedURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != expectedStatusCode {
+		return fmt.Errorf("expected status code %d when downloading presigned URL %s, but got %d", expectedStatusCode, presignedURL, resp.StatusCode)
+	}
+
+	for header, expectedValue := range expectedHeaders {
+		actualValue := resp.Header.Get(header)
+		if actualValue != expectedValue {
+			return fmt.Errorf("expected response header %s=%q when downloading presigned URL %s, but got %q", header, expectedValue, presignedURL, actualValue)
+		}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if string(body) != expectedBody {
+		return fmt.Errorf("expected body %q when downloading presigned URL %s, but got %q", expectedBody, presignedURL, string(body))
+	}
+
+	return nil
+}
+
+// S3CORSRule models a single CORS rule on an S3 bucket, mirroring the shape of Terraform's
+// aws_s3_bucket cors_rule block.
+type S3CORSRule struct {
+	AllowedHeaders []string
+	AllowedMethods []string
+	AllowedOrigins []string
+	ExposeHeaders  []string
+	MaxAgeSeconds  int32
+}
+
+// PutS3BucketCORS applies the given CORS rules to an S3 bucket, replacing any existing CORS configuration.
+func PutS3BucketCORS(t testing.TestingT, region string, bucket string, rules []S3CORSRule) {
+	err := PutS3BucketCORSE(t, region, bucket, rules)
+	require.NoError(t, err)
+}
+
+// PutS3BucketCORSE applies the given CORS rules to an S3 bucket, replacing any existing CORS configuration.
+func PutS3BucketCORSE(t testing.TestingT, region string, bucket string, rules []S3CORSRule) error {
+	logger.Default.Logf(t, "Applying CORS configuration for bucket %s in %s", bucket, region)
+
+	s3Client, err := NewS3ClientE(t, region)
+	if err != nil {
+		return err
+	}
+
+	corsRules := make([]types.CORSRule, 0, len(rules))
+	for _, rule := range rules {
+		corsRules = append(corsRules, types.CORSRule{
+			AllowedHeaders: rule.AllowedHeaders,
+			AllowedMethods: rule.AllowedMethods,
+			AllowedOrigins: rule.AllowedOrigins,
+			ExposeHeaders:  rule.ExposeHeaders,
+			MaxAgeSeconds:  aws.Int32(rule.MaxAgeSeconds),
+		})
+	}
+
+	input := &s3.PutBucketCorsInput{
+		Bucket: aws.String(bucket),
+		CORSConfiguration: &types.CORSConfiguration{
+			CORSRules: corsRules,
+		},
+	}
+
+	_, err = s3Client.PutBucketCors(context.Background(), input)
+	return err
+}
+
+// GetS3BucketCORS fetches the CORS rules configured on the given S3 bucket.
+func GetS3BucketCORS(t testing.TestingT, region string, bucket string) []S3CORSRule {
+	rules, err := GetS3BucketCORSE(t, region, bucket)
+	require.NoError(t, err)
+
+	return rules
+}
+
+// GetS3BucketCORSE fetches the CORS rules configured on the given S3 bucket.
+func GetS3BucketCORSE(t testing.TestingT, region string, bucket string) ([]S3CORSRule, error) {
+	s3Client, err := NewS3ClientE(t, region)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := s3Client.GetBucketCors(context.Background(), &s3.GetBucketCorsInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]S3CORSRule, 0, len(out.CORSRules))
+	for _, rule := range out.CORSRules {
+		rules = append(rules, S3CORSRule{
+			AllowedHeaders: rule.AllowedHeaders,
+			AllowedMethods: rule.AllowedMethods,
+			AllowedOrigins: rule.AllowedOrigins,
+			ExposeHeaders:  rule.ExposeHeaders,
+			MaxAgeSeconds:  aws.ToInt32(rule.MaxAgeSeconds),
+		})
+	}
+
+	return rules, nil
+}
+
+// DeleteS3BucketCORS removes the CORS configuration from the given S3 bucket.
+func DeleteS3BucketCORS(t testing.TestingT, region string, bucket string) {
+	err := DeleteS3BucketCORSE(t, region, bucket)
+	require.NoError(t, err)
+}
+
+// DeleteS3BucketCORSE removes the CORS configuration from the given S3 bucket.
+func DeleteS3BucketCORSE(t testing.TestingT, region string, bucket string) error {
+	logger.Default.Logf(t, "Deleting CORS configuration for bucket %s in %s", bucket, region)
+
+	s3Client, err := NewS3ClientE(t, region)
+	if err != nil {
+		return err
+	}
+
+	_, err = s3Client.DeleteBucketCors(context.Background(), &s3.DeleteBucketCorsInput{
+		Bucket: aws.String(bucket),
+	})
+	return err
+}
+
+// AssertS3BucketCORSRuleExists checks that the given S3 bucket has a CORS rule allowing the given origin and
+// method and fails the test if it does not.
+func AssertS3BucketCORSRuleExists(t testing.TestingT, region string, bucket string, origin string, method string) {
+	err := AssertS3BucketCORSRuleExistsE(t, region, bucket, origin, method)
+	require.NoError(t, err)
+}
+
+// AssertS3BucketCORSRuleExistsE checks that the given S3 bucket has a CORS rule allowing the given origin and
+// method and returns an error if it does not.
+func AssertS3BucketCORSRuleExistsE(t testing.TestingT, region string, bucket string, origin string, method string) error {
+	rules, err := GetS3BucketCORSE(t, region, bucket)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		if containsString(rule.AllowedOrigins, origin) && containsString(rule.AllowedMethods, method) {
+			return nil
+		}
+	}
+
+	return S3CORSRuleNotFoundErr{Bucket: bucket, Region: region, Origin: origin, Method: method}
+}
+
+// containsString returns true if the given slice contains the given value, or if the slice contains the
+// wildcard "*".
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target || value == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// S3BucketEncryptionConfig models the default server-side encryption applied to new objects in an S3 bucket,
+// supporting both SSE-S3 (AES256) and SSE-KMS (aws:kms).
+type S3BucketEncryptionConfig struct {
+	// SSEAlgorithm is either "AES256" or "aws:kms".
+	SSEAlgorithm string
+	// KMSMasterKeyID is the KMS key ID or ARN to use when SSEAlgorithm is "aws:kms". Leave empty to use the
+	// AWS managed S3 key (aws/s3).
+	KMSMasterKeyID string
+	// BucketKeyEnabled enables an S3 Bucket Key to reduce the cost of SSE-KMS requests.
+	BucketKeyEnabled bool
+}
+
+// PutS3BucketEncryption applies the given default server-side encryption configuration to an S3 bucket.
+func PutS3BucketEncryption(t testing.TestingT, region string, bucket string, config S3BucketEncryptionConfig) {
+	err := PutS3BucketEncryptionE(t, region, bucket, config)
+	require.NoError(t, err)
+}
+
+// PutS3BucketEncryptionE applies the given default server-side encryption configuration to an S3 bucket.
+func PutS3BucketEncryptionE(t testing.TestingT, region string, bucket string, config S3BucketEncryptionConfig) error {
+	logger.Default.Logf(t, "Applying default encryption configuration for bucket %s in %s", bucket, region)
+
+	s3Client, err := NewS3ClientE(t, region)
+	if err != nil {
+		return err
+	}
+
+	rule := types.ServerSideEncryptionRule{
+		ApplyServerSideEncryptionByDefault: &types.ServerSideEncryptionByDefault{
+			SSEAlgorithm: types.ServerSideEncryption(config.SSEAlgorithm),
+		},
+		BucketKeyEnabled: aws.Bool(config.BucketKeyEnabled),
+	}
+
+	if config.KMSMasterKeyID != "" {
+		rule.ApplyServerSideEncryptionByDefault.KMSMasterKeyID = aws.String(config.KMSMasterKeyID)
+	}
+
+	input := &s3.PutBucketEncryptionInput{
+		Bucket: aws.String(bucket),
+		ServerSideEncryptionConfiguration: &types.ServerSideEncryptionConfiguration{
+			Rules: []types.ServerSideEncryptionRule{rule},
+		},
+	}
+
+	_, err = s3Client.PutBucketEncryption(context.Background(), input)
+	return err
+}
+
+// GetS3BucketEncryption fetches the default server-side encryption configuration for the given S3 bucket.
+func GetS3BucketEncryption(t testing.TestingT, region string, bucket string) S3BucketEncryptionConfig {
+	config, err := GetS3BucketEncryptionE(t, region, bucket)
+	require.NoError(t, err)
+
+	return config
+}
+
+// GetS3BucketEncryptionE fetches the default server-side encryption configuration for the given S3 bucket.
+func GetS3BucketEncryptionE(t testing.TestingT, region string, bucket string) (S3BucketEncryptionConfig, error) {
+	s3Client, err := NewS3ClientE(t, region)
+	if err != nil {
+		return S3BucketEncryptionConfig{}, err
+	}
+
+	out, err := s3Client.GetBucketEncryption(context.Background(), &s3.GetBucketEncryptionInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		return S3BucketEncryptionConfig{}, err
+	}
+
+	if out.ServerSideEncryptionConfiguration == nil || len(out.ServerSideEncryptionConfiguration.Rules) == 0 {
+		return S3BucketEncryptionConfig{}, S3BucketEncryptionNotEnabledErr{Bucket: bucket, Region: region}
+	}
+
+	byDefault := out.ServerSideEncryptionConfiguration.Rules[0].ApplyServerSideEncryptionByDefault
+	return S3BucketEncryptionConfig{
+		SSEAlgorithm:     string(byDefault.SSEAlgorithm),
+		KMSMasterKeyID:   aws.ToString(byDefault.KMSMasterKeyID),
+		BucketKeyEnabled: aws.ToBool(out.ServerSideEncryptionConfiguration.Rules[0].BucketKeyEnabled),
+	}, nil
+}
+
+// AssertS3BucketEncryptionEnabled checks that the given S3 bucket has default server-side encryption enabled
+// and fails the test if it does not.
+func AssertS3BucketEncryptionEnabled(t testing.TestingT, region string, bucket string) {
+	err := AssertS3BucketEncryptionEnabledE(t, region, bucket)
+	require.NoError(t, err)
+}
+
+// AssertS3BucketEncryptionEnabledE checks that the given S3 bucket has default server-side encryption enabled
+// and returns an error if it does not.
+func AssertS3BucketEncryptionEnabledE(t testing.TestingT, region string, bucket string) error {
+	_, err := GetS3BucketEncryptionE(t, region, bucket)
+	return err
+}
+
+// AssertS3BucketKMSEncryption checks that the given S3 bucket has default SSE-KMS encryption enabled with the
+// expected KMS key ARN and fails the test if it does not.
+func AssertS3BucketKMSEncryption(t testing.TestingT, region string, bucket string, expectedKmsKeyArn string) {
+	err := AssertS3BucketKMSEncryptionE(t, region, bucket, expectedKmsKeyArn)
+	require.NoError(t, err)
+}
+
+// AssertS3BucketKMSEncryptionE checks that the given S3 bucket has default SSE-KMS encryption enabled with the
+// expected KMS key ARN and returns an error if it does not.
+func AssertS3BucketKMSEncryptionE(t testing.TestingT, region string, bucket string, expectedKmsKeyArn string) error {
+	config, err := GetS3BucketEncryptionE(t, region, bucket)
+	if err != nil {
+		return err
+	}
+
+	if config.SSEAlgorithm != string(types.ServerSideEncryptionAwsKms) {
+		return S3UnexpectedEncryptionAlgorithmErr{Bucket: bucket, Region: region, Expected: string(types.ServerSideEncryptionAwsKms), Actual: config.SSEAlgorithm}
+	}
+
+	if config.KMSMasterKeyID != expectedKmsKeyArn {
+		return S3UnexpectedKMSKeyErr{Bucket: bucket, Region: region, Expected: expectedKmsKeyArn, Actual: config.KMSMasterKeyID}
+	}
+
+	return nil
+}
+
+// GetS3ObjectServerSideEncryption fetches the server-side encryption algorithm and KMS key ID (if any) applied
+// to the given S3 object via a HeadObject call.
+func GetS3ObjectServerSideEncryption(t testing.TestingT, region string, bucket string, key string) (string, string) {
+	algorithm, kmsKeyID, err := GetS3ObjectServerSideEncryptionE(t, region, bucket, key)
+	require.NoError(t, err)
+
+	return algorithm, kmsKeyID
+}
+
+// GetS3ObjectServerSideEncryptionE fetches the server-side encryption algorithm and KMS key ID (if any) applied
+// to the given S3 object via a HeadObject call.
+func GetS3ObjectServerSideEncryptionE(t testing.TestingT, region string, bucket string, key string) (string, string, error) {
+	s3Client, err := NewS3ClientE(t, region)
+	if err != nil {
+		return "", "", err
+	}
+
+	out, err := s3Client.HeadObject(context.Background(), &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	return string(out.ServerSideEncryption), aws.ToString(out.SSEKMSKeyId), nil
+}
+
+// S3AccessLoggingNotEnabledErr is a custom error that occurs when acess logging hasn't been enabled on the S3 Bucket
+type S3AccessLoggingNotEnabledErr struct {
+	OriginBucket string
+	Region       string
+}
+
+func (err S3AccessLoggingNotEnabledErr) Error() string {
+	return fmt.Sprintf("Server Acess Logging hasn't been enabled for S3 Bucket %s in region %s", err.OriginBucket, err.Region)
+}
+
+// S3CORSRuleNotFoundErr is a custom error that occurs when an S3 bucket has no CORS rule matching the expected
+// origin and method.
+type S3CORSRuleNotFoundErr struct {
+	Bucket string
+	Region string
+	Origin string
+	Method string
+}
+
+func (err S3CORSRuleNotFoundErr) Error() string {
+	return fmt.Sprintf("S3 Bucket %s in region %s has no CORS rule allowing origin %s and method %s", err.Bucket, err.Region, err.Origin, err.Method)
+}
+
+// S3BucketEncryptionNotEnabledErr is a custom error that occurs when default server-side encryption hasn't been
+// enabled on the S3 Bucket.
+type S3BucketEncryptionNotEnabledErr struct {
+	Bucket string
+	Region string
+}
+
+func (err S3BucketEncryptionNotEnabledErr) Error() string {
+	return fmt.Sprintf("Default encryption hasn't been enabled for S3 Bucket %s in region %s", err.Bucket, err.Region)
+}
+
+// S3UnexpectedEncryptionAlgorithmErr is a custom error that occurs when an S3 Bucket's default encryption
+// algorithm doesn't match what was expected.
+type S3UnexpectedEncryptionAlgorithmErr struct {
+	Bucket   string
+	Region   string
+	Expected string
+	Actual   string
+}
+
+func (err S3UnexpectedEncryptionAlgorithmErr) Error() string {
+	return fmt.Sprintf("Expected S3 Bucket %s in region %s to use encryption algorithm %s, but got %s", err.Bucket, err.Region, err.Expected, err.Actual)
+}
+
+// S3UnexpectedKMSKeyErr is a custom error that occurs when an S3 Bucket's default SSE-KMS key doesn't match what
+// was expected.
+type S3UnexpectedKMSKeyErr struct {
+	Bucket   string
+	Region   string
+	Expected string
+	Actual   string
+}
+
+func (err S3UnexpectedKMSKeyErr) Error() string {
+	return fmt.Sprintf("Expected S3 Bucket %s in region %s to use KMS key %s, but got %s", err.Bucket, err.Region, err.Expected, err.Actual)
+}
+
+// S3LifecycleRuleNotFoundErr is a custom error that occurs when an S3 bucket has no lifecycle rule matching the
+// expected rule ID.
+type S3LifecycleRuleNotFoundErr struct {
+	Bucket string
+	Region string
+	RuleID string
+}
+
+func (err S3LifecycleRuleNotFoundErr) Error() string {
+	return fmt.Sprintf("S3 Bucket %s in region %s has no lifecycle rule with ID %s", err.Bucket, err.Region, err.RuleID)
+}
+
+// S3ObjectLockNotEnabledErr is a custom error that occurs when Object Lock hasn't been enabled on the S3 Bucket.
+type S3ObjectLockNotEnabledErr struct {
+	Bucket string
+	Region string
+}
+
+func (err S3ObjectLockNotEnabledErr) Error() string {
+	return fmt.Sprintf("Object Lock hasn't been enabled for S3 Bucket %s in region %s", err.Bucket, err.Region)
 }